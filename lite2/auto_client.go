@@ -0,0 +1,144 @@
+package lite
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// AutoClient builds on top of Client, periodically pulling the latest
+// header from its primary provider and advancing the trusted state via
+// skipping verification. It exposes TrustedHeaders and Errs channels so
+// relayers and wallets can run a light client as a long-lived service
+// instead of a one-shot verifier.
+type AutoClient struct {
+	client       *Client
+	updatePeriod time.Duration
+
+	trustedHeaders chan *types.SignedHeader
+	errs           chan error
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewAutoClient returns an AutoClient driven by client, polling for new
+// headers every updatePeriod. Call Start to begin polling.
+func NewAutoClient(client *Client, updatePeriod time.Duration) *AutoClient {
+	return &AutoClient{
+		client:       client,
+		updatePeriod: updatePeriod,
+
+		// Buffered by one so a slow consumer can't block the update loop;
+		// a stale value is simply overwritten by coalesceHeader/coalesceErr.
+		trustedHeaders: make(chan *types.SignedHeader, 1),
+		errs:           make(chan error, 1),
+	}
+}
+
+// TrustedHeaders returns a channel of the headers the AutoClient has
+// verified and trusted. Only the most recently verified header is kept
+// buffered; slow consumers miss intermediate updates rather than blocking
+// verification.
+func (ac *AutoClient) TrustedHeaders() <-chan *types.SignedHeader {
+	return ac.trustedHeaders
+}
+
+// Errs returns a channel of errors encountered while updating, including a
+// warning once the trusted state is within trustingPeriod/2 of expiring.
+func (ac *AutoClient) Errs() <-chan error {
+	return ac.errs
+}
+
+// Start begins the background update loop. It is safe to call Start again
+// after Stop.
+func (ac *AutoClient) Start() {
+	ac.quit = make(chan struct{})
+	ac.done = make(chan struct{})
+	go ac.loop()
+}
+
+// Stop ends the background update loop and waits for it to exit.
+func (ac *AutoClient) Stop() {
+	close(ac.quit)
+	<-ac.done
+}
+
+func (ac *AutoClient) loop() {
+	defer close(ac.done)
+
+	ticker := time.NewTicker(ac.updatePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ac.quit:
+			return
+		case <-ticker.C:
+			ac.update()
+		}
+	}
+}
+
+func (ac *AutoClient) update() {
+	now := time.Now()
+
+	if warning := ac.expiryWarning(now); warning != nil {
+		ac.coalesceErr(warning)
+		return
+	}
+
+	latest, err := ac.client.primary.SignedHeader(0)
+	if err != nil {
+		ac.coalesceErr(errors.Wrap(err, "failed to fetch latest header from primary"))
+		return
+	}
+
+	newHeader, err := ac.client.VerifyHeaderAtHeight(latest.Height, now)
+	if err != nil {
+		ac.coalesceErr(errors.Wrap(err, "failed to verify latest header"))
+		return
+	}
+
+	ac.coalesceHeader(newHeader)
+}
+
+// expiryWarning returns a non-nil error once the trusted state is within
+// trustingPeriod/2 of expiring, so advancement stops instead of racing an
+// expiration it can no longer recover from subjectively.
+func (ac *AutoClient) expiryWarning(now time.Time) error {
+	v := ac.client.verifier
+	expiresAt := v.state.LastHeader.Time.Add(v.trustingPeriod)
+	halfLife := v.state.LastHeader.Time.Add(v.trustingPeriod / 2)
+
+	if !now.Before(halfLife) {
+		return errors.Errorf(
+			"trusted header is more than half-way through its trusting period (expires %v, now %v): "+
+				"update the light client before it expires",
+			expiresAt, now,
+		)
+	}
+	return nil
+}
+
+// coalesceHeader overwrites any unconsumed header with the latest one,
+// rather than blocking on a slow consumer.
+func (ac *AutoClient) coalesceHeader(h *types.SignedHeader) {
+	select {
+	case <-ac.trustedHeaders:
+	default:
+	}
+	ac.trustedHeaders <- h
+}
+
+// coalesceErr overwrites any unconsumed error with the latest one, rather
+// than blocking on a slow consumer.
+func (ac *AutoClient) coalesceErr(err error) {
+	select {
+	case <-ac.errs:
+	default:
+	}
+	ac.errs <- err
+}
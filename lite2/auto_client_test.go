@@ -0,0 +1,49 @@
+package lite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func newAutoClientAt(lastHeaderTime time.Time, trustingPeriod time.Duration) *AutoClient {
+	v := &Verifier{
+		trustingPeriod: trustingPeriod,
+		state: &TrustedState{
+			LastHeader: &types.Header{Time: lastHeaderTime},
+		},
+	}
+	return &AutoClient{client: &Client{verifier: v}}
+}
+
+func TestAutoClientExpiryWarning(t *testing.T) {
+	lastHeaderTime := time.Unix(1000, 0)
+	trustingPeriod := 100 * time.Second
+
+	testCases := []struct {
+		name    string
+		now     time.Time
+		wantErr bool
+	}{
+		{"well before half-life", lastHeaderTime.Add(10 * time.Second), false},
+		{"just before half-life", lastHeaderTime.Add(50 * time.Second).Add(-time.Nanosecond), false},
+		{"exactly at half-life", lastHeaderTime.Add(50 * time.Second), true},
+		{"past half-life, before expiry", lastHeaderTime.Add(90 * time.Second), true},
+		{"past expiry", lastHeaderTime.Add(200 * time.Second), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac := newAutoClientAt(lastHeaderTime, trustingPeriod)
+			err := ac.expiryWarning(tc.now)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package lite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/lite2/provider"
+	"github.com/tendermint/tendermint/lite2/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+const defaultMaxRetryAttempts = 10
+
+// ErrConflictingHeaders is returned when two providers have conflicting
+// views of the chain for the same height, i.e. a fork has been detected.
+type ErrConflictingHeaders struct {
+	Primary  provider.Provider
+	Witness  provider.Provider
+	Expected *types.SignedHeader
+	Actual   *types.SignedHeader
+}
+
+func (e ErrConflictingHeaders) Error() string {
+	return fmt.Sprintf("primary %s and witness %s disagree on header at height %d",
+		e.Primary.ChainID(), e.Witness.ChainID(), e.Expected.Height)
+}
+
+// Client wraps a Verifier with a primary Provider to fetch new headers from,
+// a set of witness Providers to cross-check the primary against, and a
+// Store to persist the trusted headers the Verifier has accepted. It is the
+// piece that turns the bare Verifier into a usable, disk-backed light
+// client.
+type Client struct {
+	verifier *Verifier
+
+	primary   provider.Provider
+	witnesses []provider.Provider
+
+	trustedStore store.Store
+
+	maxRetryAttempts int
+}
+
+// ClientOption allows customizing Client.
+type ClientOption func(*Client)
+
+// MaxRetryAttempts changes the number of attempts VerifyHeaderAtHeight makes
+// to fetch, verify and cross-check a header before giving up and returning
+// an error.
+func MaxRetryAttempts(attempts int) ClientOption {
+	return func(c *Client) {
+		c.maxRetryAttempts = attempts
+	}
+}
+
+// NewClient returns a Client, starting from verifier's trusted state and
+// persisting to trustedStore from here on. verifier is used to validate
+// every header fetched from primary, which is then cross-checked against
+// witnesses.
+func NewClient(
+	verifier *Verifier,
+	primary provider.Provider,
+	witnesses []provider.Provider,
+	trustedStore store.Store,
+	options ...ClientOption,
+) (*Client, error) {
+	if primary == nil {
+		return nil, errors.New("primary provider must not be nil")
+	}
+	if len(witnesses) == 0 {
+		return nil, errors.New("witness list must not be empty")
+	}
+
+	c := &Client{
+		verifier:         verifier,
+		primary:          primary,
+		witnesses:        witnesses,
+		trustedStore:     trustedStore,
+		maxRetryAttempts: defaultMaxRetryAttempts,
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	return c, nil
+}
+
+// VerifyHeaderAtHeight fetches the header and validator set at height from
+// the primary and runs skipping verification against the currently trusted
+// state, but does not yet commit the result to the Verifier: it first
+// cross-checks the candidate header against the witnesses, and only once
+// that succeeds does it persist the new trusted state to the store and
+// commit it to the Verifier. If a witness disagrees with the primary, an
+// ErrConflictingHeaders carrying both headers is returned, and neither the
+// Verifier nor the store are touched. If a witness merely fails to confirm
+// (e.g. it's behind or unreachable), the witness set is rotated and the
+// attempt is retried.
+func (c *Client) VerifyHeaderAtHeight(height int64, now time.Time) (*types.SignedHeader, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < c.maxRetryAttempts; attempt++ {
+		newHeader, candidate, err := c.verifier.CandidateAtHeight(headerProvider{c.primary}, height, now)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := c.compareWithWitnesses(newHeader); err != nil {
+			if _, ok := err.(ErrConflictingHeaders); ok {
+				return nil, err
+			}
+			lastErr = err
+			c.rotateWitness()
+			continue
+		}
+
+		if err := c.trustedStore.SaveTrustedHeaderAndVals(newHeader, candidate.Validators); err != nil {
+			return nil, errors.Wrap(err, "failed to save new trusted state")
+		}
+		c.verifier.Commit(candidate)
+
+		return newHeader, nil
+	}
+
+	return nil, errors.Wrap(lastErr, "exhausted max retry attempts")
+}
+
+// compareWithWitnesses cross-checks newHeader, obtained from the primary,
+// against the witnesses' view of the chain at the same height, in order,
+// stopping as soon as one witness confirms it. It returns
+// ErrConflictingHeaders on an outright disagreement (a fork), and a plain
+// error if no witness could be reached to confirm newHeader at all, so the
+// caller knows to rotate witnesses and retry rather than treat a transient
+// witness outage as settled agreement. Stopping at the first confirmation,
+// rather than always consulting every witness, is what makes rotateWitness
+// meaningfully change which witness gets consulted first on retry.
+func (c *Client) compareWithWitnesses(newHeader *types.SignedHeader) error {
+	if len(c.witnesses) == 0 {
+		return nil
+	}
+
+	var unreachable []error
+
+	for _, w := range c.witnesses {
+		altHeader, err := w.SignedHeader(newHeader.Height)
+		if err != nil {
+			// A witness that can't serve the height yet might simply be
+			// behind; it's not in conflict, but it also didn't confirm, so
+			// fall through to the next witness.
+			unreachable = append(unreachable, errors.Wrapf(err, "witness %s", w.ChainID()))
+			continue
+		}
+
+		if !altHeader.Commit.BlockID.Equals(newHeader.Commit.BlockID) {
+			return ErrConflictingHeaders{
+				Primary:  c.primary,
+				Witness:  w,
+				Expected: newHeader,
+				Actual:   altHeader,
+			}
+		}
+
+		return nil
+	}
+
+	return errors.Errorf("no witness could confirm header at height %d: %v", newHeader.Height, unreachable)
+}
+
+// rotateWitness drops the first witness to the back of the line, so the
+// next retry tries a different one first.
+func (c *Client) rotateWitness() {
+	if len(c.witnesses) < 2 {
+		return
+	}
+	c.witnesses = append(c.witnesses[1:], c.witnesses[0])
+}
+
+// headerProvider adapts a provider.Provider to the HeaderProvider interface
+// the Verifier's skipping verification expects.
+type headerProvider struct {
+	provider.Provider
+}
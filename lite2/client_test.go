@@ -0,0 +1,94 @@
+package lite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/lite2/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+type fakeProvider struct {
+	chainID string
+	headers map[int64]*types.SignedHeader
+	err     error
+}
+
+func (p *fakeProvider) ChainID() string { return p.chainID }
+
+func (p *fakeProvider) SignedHeader(height int64) (*types.SignedHeader, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	sh, ok := p.headers[height]
+	if !ok {
+		return nil, provider.ErrSignedHeaderNotFound
+	}
+	return sh, nil
+}
+
+func (p *fakeProvider) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	return nil, nil
+}
+
+func signedHeaderAt(height int64, blockHash []byte) *types.SignedHeader {
+	return &types.SignedHeader{
+		Header: &types.Header{Height: height},
+		Commit: &types.Commit{BlockID: types.BlockID{Hash: blockHash}},
+	}
+}
+
+func TestClientCompareWithWitnesses_Agree(t *testing.T) {
+	newHeader := signedHeaderAt(10, []byte("A"))
+	witness := &fakeProvider{chainID: "w1", headers: map[int64]*types.SignedHeader{10: signedHeaderAt(10, []byte("A"))}}
+
+	c := &Client{witnesses: []provider.Provider{witness}}
+
+	assert.NoError(t, c.compareWithWitnesses(newHeader))
+}
+
+func TestClientCompareWithWitnesses_Conflict(t *testing.T) {
+	primary := &fakeProvider{chainID: "primary"}
+	newHeader := signedHeaderAt(10, []byte("A"))
+	witness := &fakeProvider{chainID: "w1", headers: map[int64]*types.SignedHeader{10: signedHeaderAt(10, []byte("B"))}}
+
+	c := &Client{primary: primary, witnesses: []provider.Provider{witness}}
+
+	err := c.compareWithWitnesses(newHeader)
+	require.Error(t, err)
+	_, ok := err.(ErrConflictingHeaders)
+	assert.True(t, ok, "expected ErrConflictingHeaders, got %T: %v", err, err)
+}
+
+func TestClientCompareWithWitnesses_NoConfirmation(t *testing.T) {
+	newHeader := signedHeaderAt(10, []byte("A"))
+	downWitness := &fakeProvider{chainID: "w1", err: provider.ErrSignedHeaderNotFound}
+
+	c := &Client{witnesses: []provider.Provider{downWitness}}
+
+	err := c.compareWithWitnesses(newHeader)
+	require.Error(t, err)
+	_, ok := err.(ErrConflictingHeaders)
+	assert.False(t, ok, "an unreachable witness is not a conflict, so rotation/retry can kick in")
+}
+
+func TestClientCompareWithWitnesses_NoWitnesses(t *testing.T) {
+	newHeader := signedHeaderAt(10, []byte("A"))
+	c := &Client{}
+
+	assert.NoError(t, c.compareWithWitnesses(newHeader))
+}
+
+func TestClientRotateWitness(t *testing.T) {
+	w1 := &fakeProvider{chainID: "w1"}
+	w2 := &fakeProvider{chainID: "w2"}
+	w3 := &fakeProvider{chainID: "w3"}
+	c := &Client{witnesses: []provider.Provider{w1, w2, w3}}
+
+	c.rotateWitness()
+
+	require.Len(t, c.witnesses, 3)
+	assert.Equal(t, []provider.Provider{w2, w3, w1}, c.witnesses)
+}
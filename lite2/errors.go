@@ -0,0 +1,60 @@
+package lite
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrOldHeaderExpired means the old (trusted) header has expired according
+// to the trusting period and current time. If so, the light client must be
+// reset subjectively.
+type ErrOldHeaderExpired struct {
+	At  time.Time
+	Now time.Time
+}
+
+func (e ErrOldHeaderExpired) Error() string {
+	return fmt.Sprintf("old header has expired at %v, which is before now (%v)", e.At, e.Now)
+}
+
+// ErrInvalidHeader means the header a Verifier tried to verify failed basic
+// validation, e.g. it had a stale timestamp, a height not greater than the
+// trusted header's, or a validator set hash that didn't match.
+type ErrInvalidHeader struct {
+	Reason error
+}
+
+func (e ErrInvalidHeader) Error() string {
+	return fmt.Sprintf("invalid header: %v", e.Reason)
+}
+
+// ErrNewValSetCantBeTrusted means the new validator set cannot be trusted
+// because less than trustLevel of the old (trusted) validator set has
+// signed the commit for the new header.
+type ErrNewValSetCantBeTrusted struct {
+	Reason error
+}
+
+func (e ErrNewValSetCantBeTrusted) Error() string {
+	return fmt.Sprintf("new validator set cannot be trusted: %v", e.Reason)
+}
+
+// ErrNotEnoughVotingPowerSigned means the commit for the new header did not
+// reach +2/3 of the new validator set's voting power.
+type ErrNotEnoughVotingPowerSigned struct {
+	Reason error
+}
+
+func (e ErrNotEnoughVotingPowerSigned) Error() string {
+	return fmt.Sprintf("not enough voting power signed: %v", e.Reason)
+}
+
+// isTrustLevelFailure reports whether err indicates that fewer than
+// trustLevel of the previously trusted validators signed a header, as
+// opposed to the header itself being invalid or failing the final +2/3
+// check. It's the only failure mode that bisecting the gap to an earlier
+// height could possibly resolve.
+func isTrustLevelFailure(err error) bool {
+	_, ok := err.(ErrNewValSetCantBeTrusted)
+	return ok
+}
@@ -0,0 +1,28 @@
+package lite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTrustLevelFailure(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"trust level failure", ErrNewValSetCantBeTrusted{Reason: errors.New("not enough sigs")}, true},
+		{"invalid header", ErrInvalidHeader{Reason: errors.New("stale time")}, false},
+		{"not enough voting power", ErrNotEnoughVotingPowerSigned{Reason: errors.New("nope")}, false},
+		{"expired header", ErrOldHeaderExpired{}, false},
+		{"unrelated error", errors.New("boom"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isTrustLevelFailure(tc.err))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package lite
+
+import "github.com/pkg/errors"
+
+// Fraction represents a rational number p/q, used in place of a float so
+// the trust level threshold compares exactly instead of being subject to
+// floating-point rounding.
+type Fraction struct {
+	Numerator   int64
+	Denominator int64
+}
+
+// ValidateTrustLevel returns an error if f is not within [1/3, 1], the only
+// trust levels for which skipping verification is safe: below 1/3 even a
+// single honest validator carrying over from the trusted set could not be
+// guaranteed to have signed, and above 1 is meaningless.
+func ValidateTrustLevel(f Fraction) error {
+	if f.Denominator <= 0 {
+		return errors.Errorf("denominator must be positive, got %d", f.Denominator)
+	}
+	if f.Numerator*3 < f.Denominator || f.Numerator > f.Denominator {
+		return errors.Errorf("trustLevel must be within [1/3, 1], got %d/%d", f.Numerator, f.Denominator)
+	}
+	return nil
+}
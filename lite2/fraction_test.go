@@ -0,0 +1,37 @@
+package lite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTrustLevel(t *testing.T) {
+	testCases := []struct {
+		name    string
+		f       Fraction
+		wantErr bool
+	}{
+		{"default 1/3", Fraction{1, 3}, false},
+		{"lower bound 1/3 exactly", Fraction{1, 3}, false},
+		{"equivalent lower bound 2/6", Fraction{2, 6}, false},
+		{"upper bound 1/1", Fraction{1, 1}, false},
+		{"in between, 1/2", Fraction{1, 2}, false},
+		{"just below 1/3", Fraction{1, 4}, true},
+		{"above 1", Fraction{3, 2}, true},
+		{"zero", Fraction{0, 1}, true},
+		{"zero denominator", Fraction{1, 0}, true},
+		{"negative denominator", Fraction{1, -3}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTrustLevel(tc.f)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
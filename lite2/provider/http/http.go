@@ -0,0 +1,91 @@
+package http
+
+import (
+	"github.com/pkg/errors"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/tendermint/tendermint/lite2/provider"
+)
+
+// http fetches signed headers and validator sets from a full node over the
+// RPC client, i.e. it's a provider.Provider backed by a remote peer.
+type http struct {
+	chainID string
+	client  rpcclient.Client
+}
+
+// New creates a HTTP provider, which is using the given node's RPC client to
+// fetch signed headers and validator sets for a given chainID. A single
+// remote can serve as many chain IDs as it has blocks for.
+func New(chainID, remote string) (provider.Provider, error) {
+	httpClient, err := rpcclient.NewHTTP(remote, "/websocket")
+	if err != nil {
+		return nil, err
+	}
+	return NewWithClient(chainID, httpClient), nil
+}
+
+// NewWithClient allows you to pass in a custom rpcclient.Client, e.g. a
+// mock in tests.
+func NewWithClient(chainID string, client rpcclient.Client) provider.Provider {
+	return &http{
+		chainID: chainID,
+		client:  client,
+	}
+}
+
+// ChainID returns the chain ID this provider is configured for.
+func (p *http) ChainID() string {
+	return p.chainID
+}
+
+func (p *http) SignedHeader(height int64) (*types.SignedHeader, error) {
+	h, err := invalidHeightToNil(height)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := p.client.Commit(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch commit")
+	}
+
+	return &commit.SignedHeader, nil
+}
+
+func (p *http) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	h, err := invalidHeightToNil(height)
+	if err != nil {
+		return nil, err
+	}
+
+	const maxPerPage = 100
+	vals := make([]*types.Validator, 0)
+	page := 1
+	for {
+		res, err := p.client.Validators(h, page, maxPerPage)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch validators")
+		}
+		vals = append(vals, res.Validators...)
+		if len(res.Validators) < maxPerPage {
+			break
+		}
+		page++
+	}
+
+	return types.NewValidatorSet(vals), nil
+}
+
+func invalidHeightToNil(height int64) (*int64, error) {
+	switch {
+	case height < 0:
+		return nil, errors.Errorf("expected height >= 0, got height %d", height)
+	case height == 0:
+		return nil, nil
+	default:
+		return &height, nil
+	}
+}
@@ -0,0 +1,27 @@
+package inprocess
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/node"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/tendermint/tendermint/lite2/provider"
+	httpp "github.com/tendermint/tendermint/lite2/provider/http"
+)
+
+// New creates an in-process provider, using a local node's RPC client
+// directly instead of going over HTTP. This is useful when the light client
+// runs inside the same process as a full node, e.g. for testing.
+func New(chainID string, n *node.Node) provider.Provider {
+	return httpp.NewWithClient(chainID, rpcclient.NewLocal(n))
+}
+
+// NewWithClient allows passing in an arbitrary in-process rpcclient.Client,
+// e.g. a mock used in tests.
+func NewWithClient(chainID string, client rpcclient.Client) (provider.Provider, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return httpp.NewWithClient(chainID, client), nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrSignedHeaderNotFound is returned when a provider can't find the
+// requested header.
+var ErrSignedHeaderNotFound = errors.New("signed header not found")
+
+// ErrValidatorSetNotFound is returned when a provider can't find the
+// requested validator set.
+var ErrValidatorSetNotFound = errors.New("validator set not found")
+
+// Provider provides the light client with signed headers and validator sets
+// for a given height, fetched from a full node. Providers are used both as
+// the primary source of new headers and as witnesses cross-checking the
+// primary's view of the chain.
+type Provider interface {
+	// ChainID returns the blockchain ID this provider serves.
+	ChainID() string
+
+	// SignedHeader returns the signed header at the given height. If height
+	// is 0, the latest signed header is returned.
+	SignedHeader(height int64) (*types.SignedHeader, error)
+
+	// ValidatorSet returns the validator set at the given height. If height
+	// is 0, the latest validator set is returned.
+	ValidatorSet(height int64) (*types.ValidatorSet, error)
+}
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	amino "github.com/tendermint/go-amino"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/tendermint/tendermint/lite2/store"
+)
+
+const (
+	sigHeaderKeyFmt = "sh/%020d"
+	valSetKeyFmt    = "vs/%020d"
+	sizeKey         = "size"
+)
+
+// dbs is a Store backed by a dbm.DB (e.g. goleveldb, boltdb, memdb), keeping
+// every trusted header and validator set the light client has ever saved
+// until Prune is called.
+type dbs struct {
+	db  dbm.DB
+	cdc *amino.Codec
+
+	mtx  sync.RWMutex
+	size uint16
+}
+
+// New returns a Store that persists trusted headers and validator sets to
+// db, encoding them with cdc.
+func New(db dbm.DB, cdc *amino.Codec) store.Store {
+	s := &dbs{db: db, cdc: cdc}
+	s.size = s.loadSize()
+	return s
+}
+
+func (s *dbs) SaveTrustedHeaderAndVals(sh *types.SignedHeader, valSet *types.ValidatorSet) error {
+	if sh == nil {
+		return errors.New("nil SignedHeader")
+	}
+	if valSet == nil {
+		return errors.New("nil ValidatorSet")
+	}
+
+	shBz, err := s.cdc.MarshalBinaryBare(sh)
+	if err != nil {
+		return fmt.Errorf("marshaling SignedHeader: %w", err)
+	}
+	vsBz, err := s.cdc.MarshalBinaryBare(valSet)
+	if err != nil {
+		return fmt.Errorf("marshaling ValidatorSet: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	b := s.db.NewBatch()
+	defer b.Close()
+	b.Set(sigHeaderKey(sh.Height), shBz)
+	b.Set(valSetKey(sh.Height+1), vsBz)
+	b.Set([]byte(sizeKey), s.cdc.MustMarshalBinaryBare(s.size+1))
+	if err := b.WriteSync(); err != nil {
+		return fmt.Errorf("writing batch: %w", err)
+	}
+
+	s.size++
+
+	return nil
+}
+
+func (s *dbs) TrustedHeader(height int64) (*types.SignedHeader, error) {
+	height, err := s.normalizeHeight(height, sigHeaderKeyFmt)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := s.db.Get(sigHeaderKey(height))
+	if err != nil {
+		return nil, fmt.Errorf("fetching signed header: %w", err)
+	}
+	if len(bz) == 0 {
+		return nil, store.ErrSignedHeaderNotFound
+	}
+
+	var sh *types.SignedHeader
+	err = s.cdc.UnmarshalBinaryBare(bz, &sh)
+	return sh, err
+}
+
+func (s *dbs) TrustedValidatorSet(height int64) (*types.ValidatorSet, error) {
+	height, err := s.normalizeHeight(height, valSetKeyFmt)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := s.db.Get(valSetKey(height))
+	if err != nil {
+		return nil, fmt.Errorf("fetching validator set: %w", err)
+	}
+	if len(bz) == 0 {
+		return nil, store.ErrValidatorSetNotFound
+	}
+
+	var valSet *types.ValidatorSet
+	err = s.cdc.UnmarshalBinaryBare(bz, &valSet)
+	return valSet, err
+}
+
+func (s *dbs) LastTrustedHeight() (int64, error) {
+	itr := s.db.ReverseIterator(
+		sigHeaderKey(1),
+		append(sigHeaderKey(1<<62), byte(0x00)),
+	)
+	defer itr.Close()
+	return s.heightFromIterator(itr)
+}
+
+func (s *dbs) FirstTrustedHeight() (int64, error) {
+	itr := s.db.Iterator(
+		sigHeaderKey(1),
+		append(sigHeaderKey(1<<62), byte(0x00)),
+	)
+	defer itr.Close()
+	return s.heightFromIterator(itr)
+}
+
+func (s *dbs) heightFromIterator(itr dbm.Iterator) (int64, error) {
+	if !itr.Valid() {
+		return -1, nil
+	}
+
+	var height int64
+	_, err := fmt.Sscanf(string(itr.Key()), sigHeaderKeyFmt, &height)
+	if err != nil {
+		return -1, fmt.Errorf("parsing height from key: %w", err)
+	}
+	return height, nil
+}
+
+func (s *dbs) Prune(size uint16) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.size <= size {
+		return nil
+	}
+
+	itr := s.db.Iterator(
+		sigHeaderKey(1),
+		append(sigHeaderKey(1<<62), byte(0x00)),
+	)
+	defer itr.Close()
+
+	numToPrune := s.size - size
+	b := s.db.NewBatch()
+	defer b.Close()
+
+	var pruned uint16
+	for ; itr.Valid() && pruned < numToPrune; itr.Next() {
+		var height int64
+		if _, err := fmt.Sscanf(string(itr.Key()), sigHeaderKeyFmt, &height); err != nil {
+			return fmt.Errorf("parsing height from key: %w", err)
+		}
+		b.Delete(sigHeaderKey(height))
+		b.Delete(valSetKey(height + 1))
+		pruned++
+	}
+
+	s.size -= pruned
+	b.Set([]byte(sizeKey), s.cdc.MustMarshalBinaryBare(s.size))
+
+	return b.WriteSync()
+}
+
+func (s *dbs) Size() uint16 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.size
+}
+
+func (s *dbs) loadSize() uint16 {
+	bz, err := s.db.Get([]byte(sizeKey))
+	if err != nil || len(bz) == 0 {
+		return 0
+	}
+	var size uint16
+	s.cdc.MustUnmarshalBinaryBare(bz, &size)
+	return size
+}
+
+// normalizeHeight resolves height == 0 ("give me the latest") to the actual
+// latest height present under keyFmt's keyspace. Validator sets are saved
+// one height ahead of the header they were fetched alongside (see
+// SaveTrustedHeaderAndVals), so the latest validator-set height is the
+// latest header height + 1, not the latest header height itself.
+func (s *dbs) normalizeHeight(height int64, keyFmt string) (int64, error) {
+	if height != 0 {
+		return height, nil
+	}
+
+	h, err := s.LastTrustedHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	if keyFmt == valSetKeyFmt {
+		return h + 1, nil
+	}
+	return h, nil
+}
+
+func sigHeaderKey(height int64) []byte {
+	return []byte(fmt.Sprintf(sigHeaderKeyFmt, height))
+}
+
+func valSetKey(height int64) []byte {
+	return []byte(fmt.Sprintf(valSetKeyFmt, height))
+}
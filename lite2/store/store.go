@@ -0,0 +1,49 @@
+package store
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrSignedHeaderNotFound is returned when a store can't find the requested
+// header.
+var ErrSignedHeaderNotFound = errors.New("signed header not found")
+
+// ErrValidatorSetNotFound is returned when a store can't find the requested
+// validator set.
+var ErrValidatorSetNotFound = errors.New("validator set not found")
+
+// Store persists the headers and validator sets a light client has decided
+// to trust, so a Client can resume from its last verified height instead of
+// verifying from a fresh root of trust on every start.
+type Store interface {
+	// SaveTrustedHeaderAndVals saves a signed header (h) and a validator set
+	// (h+1) as trusted.
+	SaveTrustedHeaderAndVals(sh *types.SignedHeader, valset *types.ValidatorSet) error
+
+	// TrustedHeader returns a trusted header at the given height. If height
+	// is 0, the latest trusted header is returned.
+	TrustedHeader(height int64) (*types.SignedHeader, error)
+
+	// TrustedValidatorSet returns a trusted validator set for the given
+	// height (H+1), where H was the height of the signed header it was
+	// saved with.
+	TrustedValidatorSet(height int64) (*types.ValidatorSet, error)
+
+	// LastTrustedHeight returns the highest trusted height, or -1 if the
+	// store is empty.
+	LastTrustedHeight() (int64, error)
+
+	// FirstTrustedHeight returns the lowest trusted height, or -1 if the
+	// store is empty.
+	FirstTrustedHeight() (int64, error)
+
+	// Prune removes headers and validator sets, keeping only the newest
+	// size of them.
+	Prune(size uint16) error
+
+	// Size returns the number of headers and validator sets currently in
+	// the store.
+	Size() uint16
+}
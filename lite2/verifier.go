@@ -9,9 +9,11 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-const (
-	defaultTrustLevel = 1 / 3
-)
+// defaultTrustLevel is 1/3, the minimum fraction of the old validator set
+// that is required to have signed a new header for it to be trusted without
+// every intermediate header. Expressing it as a float32 literal (1 / 3)
+// silently truncated to 0 via integer division; Fraction fixes that.
+var defaultTrustLevel = Fraction{Numerator: 1, Denominator: 3}
 
 // TrustedState stores the latest state trusted by a lite client, including the
 // last header and the validator set to use to verify the next header.
@@ -26,7 +28,8 @@ type Verifier struct {
 	chainID string
 
 	trustingPeriod time.Duration
-	trustLevel     float32
+	trustLevel     Fraction
+	maxClockDrift  time.Duration
 	state          *TrustedState
 }
 
@@ -35,17 +38,28 @@ type Verifier struct {
 //
 // However, in case of (frequent) changes in the validator set, the higher the
 // trustlevel is chosen, the more unlikely it becomes that Verify returns true
-// for a non-adjacent header.
-func TrustLevel(lvl float32) func(*Verifier) {
+// for a non-adjacent header. lvl must be within [1/3, 1]; NewVerifier
+// returns an error otherwise.
+func TrustLevel(lvl Fraction) func(*Verifier) {
 	return func(v *Verifier) {
 		v.trustLevel = lvl
 	}
 }
 
+// MaxClockDrift can be used to tolerate small amounts of clock drift between
+// this light client and the source of the headers it verifies. Without it,
+// a header timestamped only slightly ahead of this client's clock (e.g. due
+// to imperfect NTP sync) would be rejected as "from the future".
+func MaxClockDrift(d time.Duration) func(*Verifier) {
+	return func(v *Verifier) {
+		v.maxClockDrift = d
+	}
+}
+
 func NewVerifier(chainID string,
 	trustingPeriod time.Duration,
 	trustedState *TrustedState,
-	options ...func(*Verifier)) *Verifier {
+	options ...func(*Verifier)) (*Verifier, error) {
 
 	v := &Verifier{
 		chainID: chainID,
@@ -59,93 +73,262 @@ func NewVerifier(chainID string,
 		o(v)
 	}
 
-	return v
+	if err := ValidateTrustLevel(v.trustLevel); err != nil {
+		return nil, errors.Wrap(err, "invalid trust level")
+	}
+
+	return v, nil
 }
 
+// Verify dispatches to VerifyAdjacent or VerifyNonAdjacent depending on
+// whether newHeader is the immediate successor of the currently trusted
+// header. It is kept for backward compatibility; new callers that need to
+// distinguish a trust-level failure from a commit-verification failure
+// should call VerifyAdjacent/VerifyNonAdjacent directly.
 func (v *Verifier) Verify(newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
-	if err := v.expired(now); err != nil {
-		return err
+	if newHeader.Height == v.state.LastHeader.Height+1 {
+		return v.VerifyAdjacent(newHeader, vals, now)
+	}
+	return v.VerifyNonAdjacent(newHeader, vals, now)
+}
+
+// VerifyAdjacent verifies newHeader, whose height must be exactly
+// v.state.LastHeader.Height+1, against vals, the validator set it claims
+// for itself, requiring +2/3 of the currently trusted validators to have
+// signed it.
+func (v *Verifier) VerifyAdjacent(newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
+	if newHeader.Height != v.state.LastHeader.Height+1 {
+		return ErrInvalidHeader{
+			Reason: errors.Errorf("VerifyAdjacent requires height %d, got %d",
+				v.state.LastHeader.Height+1, newHeader.Height),
+		}
 	}
 
-	if err := v.verifyNewHeaderAndVals(newHeader, vals, now); err != nil {
+	if err := v.expiredAt(v.state, now); err != nil {
 		return err
 	}
 
-	if newHeader.Height == v.state.LastHeader.Height+1 {
-		if !bytes.Equal(newHeader.ValidatorsHash, v.state.Validators.Hash()) {
-			return errors.Errorf("expected our validators (%X) to match those from new header (%X)",
-				v.state.Validators.Hash(),
-				newHeader.ValidatorsHash,
-			)
-		}
+	return v.verifyAdjacent(v.state, newHeader, vals, now)
+}
 
-		// Ensure that +2/3 of current validators signed correctly.
-		err := vals.VerifyCommit(v.chainID, newHeader.Commit.BlockID, newHeader.Height, newHeader.Commit)
-		if err != nil {
-			return err
-		}
-	} else {
-		// Ensure that +1/3 of last trusted validators signed correctly.
-		err := v.state.Validators.VerifyCommitTrusting(v.chainID, newHeader.Commit.BlockID,
-			newHeader.Height, newHeader.Commit, v.trustLevel)
-		if err != nil {
-			return err
+// VerifyNonAdjacent verifies newHeader, whose height is more than one past
+// v.state.LastHeader.Height, by requiring trustLevel of the currently
+// trusted validators, and +2/3 of the new validators in vals, to have
+// signed it.
+func (v *Verifier) VerifyNonAdjacent(newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
+	if newHeader.Height <= v.state.LastHeader.Height+1 {
+		return ErrInvalidHeader{
+			Reason: errors.Errorf("VerifyNonAdjacent requires height > %d, got %d",
+				v.state.LastHeader.Height+1, newHeader.Height),
 		}
+	}
+
+	if err := v.expiredAt(v.state, now); err != nil {
+		return err
+	}
+
+	return v.verifyNonAdjacent(v.state, newHeader, vals, now)
+}
+
+// HeaderProvider supplies the signed headers and validator sets that the
+// skipping verification algorithm needs to fetch on demand, e.g. from a full
+// node, in order to jump from the trusted height straight to a target
+// height without requiring every intermediate header.
+type HeaderProvider interface {
+	SignedHeader(height int64) (*types.SignedHeader, error)
+	ValidatorSet(height int64) (*types.ValidatorSet, error)
+}
+
+// CandidateAtHeight runs skipping verification from the verifier's current
+// trusted state up to target, fetching any intermediate headers and
+// validator sets it needs from p, and returns the actual *types.SignedHeader
+// it verified at target along with the resulting TrustedState, *without*
+// committing it. It first tries to verify target directly against the
+// currently trusted validator set; if fewer than trustLevel of those
+// validators signed the target header, it bisects the gap at
+// mid = (trusted height + target) / 2, recursively verifies up to mid, and
+// retries from there.
+//
+// Callers that need to perform additional checks before trusting the
+// result -- e.g. cross-checking it against witnesses -- must use the
+// returned header for those checks, rather than re-fetching one from p,
+// since a misbehaving/equivocating p could otherwise serve a different
+// header than the one that was actually verified. Use CandidateAtHeight and
+// Commit instead of VerifyAtHeight whenever such extra checks are needed.
+func (v *Verifier) CandidateAtHeight(p HeaderProvider, target int64, now time.Time) (*types.SignedHeader, *TrustedState, error) {
+	if err := v.expiredAt(v.state, now); err != nil {
+		return nil, nil, err
+	}
+
+	if target <= v.state.LastHeader.Height {
+		return nil, nil, errors.Errorf("target height %d must be greater than last trusted height %d",
+			target, v.state.LastHeader.Height)
+	}
+
+	return v.verifyAtHeight(v.state, p, target, now)
+}
+
+// Commit adopts state as the verifier's new trusted state. It should only
+// ever be called with a state returned by CandidateAtHeight, after any
+// additional checks the caller wanted to run on it have passed.
+func (v *Verifier) Commit(state *TrustedState) {
+	v.state = state
+}
 
-		// Ensure that +2/3 of current validators signed correctly.
-		err = vals.VerifyCommit(v.chainID, newHeader.Commit.BlockID, newHeader.Height,
-			newHeader.Commit)
-		if err != nil {
-			return err
+// VerifyAtHeight advances the verifier's trusted state from
+// v.state.LastHeader.Height to target, fetching any intermediate headers and
+// validator sets it needs from p. The trusted state is only mutated once
+// the full chain of verifications, from the current height to target, has
+// succeeded. See CandidateAtHeight for the underlying algorithm.
+func (v *Verifier) VerifyAtHeight(p HeaderProvider, target int64, now time.Time) error {
+	_, newState, err := v.CandidateAtHeight(p, target, now)
+	if err != nil {
+		return err
+	}
+
+	v.Commit(newState)
+	return nil
+}
+
+// verifyAtHeight verifies target starting from trusted, without mutating
+// v.state, and returns the signed header it verified at target along with
+// the resulting trusted state on success.
+func (v *Verifier) verifyAtHeight(trusted *TrustedState, p HeaderProvider, target int64, now time.Time) (*types.SignedHeader, *TrustedState, error) {
+	header, err := p.SignedHeader(target)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to obtain signed header at height %d", target)
+	}
+	vals, err := p.ValidatorSet(target)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to obtain validator set at height %d", target)
+	}
+
+	if target == trusted.LastHeader.Height+1 {
+		if err := v.verifyAdjacent(trusted, header, vals, now); err != nil {
+			return nil, nil, err
 		}
+		return header, &TrustedState{LastHeader: &header.Header, Validators: vals}, nil
+	}
+
+	err = v.verifyNonAdjacent(trusted, header, vals, now)
+	if err == nil {
+		return header, &TrustedState{LastHeader: &header.Header, Validators: vals}, nil
+	}
+	if !isTrustLevelFailure(err) {
+		// The header itself is invalid (ErrInvalidHeader) or didn't reach
+		// +2/3 of the new validators (ErrNotEnoughVotingPowerSigned); that
+		// can't change by verifying a different, earlier target, so
+		// bisecting would just waste HeaderProvider round-trips.
+		return nil, nil, err
+	}
+
+	// Less than trustLevel of the trusted validators signed the target
+	// header: bisect the gap and try again from the midpoint.
+	mid := (trusted.LastHeader.Height + target) / 2
+	_, midState, err := v.verifyAtHeight(trusted, p, mid, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return v.verifyAtHeight(midState, p, target, now)
+}
+
+// verifyAdjacent verifies newHeader, whose height is trusted.LastHeader.Height+1,
+// against vals, the validator set it claims for itself.
+func (v *Verifier) verifyAdjacent(trusted *TrustedState, newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
+	if err := v.verifyNewHeaderAndVals(trusted, newHeader, vals, now); err != nil {
+		return err
 	}
 
+	if !bytes.Equal(newHeader.ValidatorsHash, trusted.Validators.Hash()) {
+		return ErrInvalidHeader{Reason: errors.Errorf("expected our validators (%X) to match those from new header (%X)",
+			trusted.Validators.Hash(),
+			newHeader.ValidatorsHash,
+		)}
+	}
+
+	// Ensure that +2/3 of current validators signed correctly.
+	if err := vals.VerifyCommit(v.chainID, newHeader.Commit.BlockID, newHeader.Height, newHeader.Commit); err != nil {
+		return ErrNotEnoughVotingPowerSigned{Reason: err}
+	}
 	return nil
 }
 
-func (v *Verifier) expired(now time.Time) error {
-	expired := v.state.LastHeader.Time.Add(v.trustingPeriod)
-	if expired.Before(now) {
-		return errors.Errorf("last header expired at %v and too old to be trusted now %v. Verifier must be reset subjectively", expired, now)
+// verifyNonAdjacent verifies newHeader, whose height is more than one past
+// trusted.LastHeader.Height, by requiring trustLevel of the last trusted
+// validators to have signed it, then +2/3 of the new validators.
+func (v *Verifier) verifyNonAdjacent(trusted *TrustedState, newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
+	if err := v.verifyNewHeaderAndVals(trusted, newHeader, vals, now); err != nil {
+		return err
+	}
+
+	// Ensure that trustLevel of last trusted validators signed correctly.
+	err := trusted.Validators.VerifyCommitTrusting(v.chainID, newHeader.Commit.BlockID,
+		newHeader.Height, newHeader.Commit, v.trustLevel)
+	if err != nil {
+		return ErrNewValSetCantBeTrusted{Reason: err}
+	}
+
+	// Ensure that +2/3 of current validators signed correctly.
+	if err := vals.VerifyCommit(v.chainID, newHeader.Commit.BlockID, newHeader.Height, newHeader.Commit); err != nil {
+		return ErrNotEnoughVotingPowerSigned{Reason: err}
+	}
+	return nil
+}
+
+func (v *Verifier) expiredAt(trusted *TrustedState, now time.Time) error {
+	expiresAt := trusted.LastHeader.Time.Add(v.trustingPeriod)
+	if expiresAt.Before(now) {
+		return ErrOldHeaderExpired{At: expiresAt, Now: now}
 	}
 	return nil
 }
 
-func (v *Verifier) verifyNewHeaderAndVals(newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
+// headerFromFuture reports whether headerTime is further in the future than
+// this verifier's clock (now) tolerates, i.e. past now+maxClockDrift. A
+// positive maxClockDrift lets NTP-synchronized clients accept legitimate
+// near-real-time headers instead of rejecting any header timestamped even
+// slightly ahead of their own clock.
+func (v *Verifier) headerFromFuture(headerTime, now time.Time) bool {
+	return !headerTime.Before(now.Add(v.maxClockDrift))
+}
+
+func (v *Verifier) verifyNewHeaderAndVals(trusted *TrustedState, newHeader *types.SignedHeader, vals *types.ValidatorSet, now time.Time) error {
 	if err := newHeader.ValidateBasic(v.chainID); err != nil {
-		return errors.Wrap(err, "newHeader.ValidateBasic failed")
+		return ErrInvalidHeader{Reason: errors.Wrap(err, "newHeader.ValidateBasic failed")}
 	}
 
-	if newHeader.Height <= v.state.LastHeader.Height {
-		return errors.Errorf("expected new header height %d to be greater than one of last header %d",
+	if newHeader.Height <= trusted.LastHeader.Height {
+		return ErrInvalidHeader{Reason: errors.Errorf("expected new header height %d to be greater than one of last header %d",
 			newHeader.Height,
-			v.state.LastHeader.Height)
+			trusted.LastHeader.Height)}
 	}
 
-	if !newHeader.Time.After(v.state.LastHeader.Time) {
-		return errors.Errorf("expected new header time %v to be after last header time %v",
+	if !newHeader.Time.After(trusted.LastHeader.Time) {
+		return ErrInvalidHeader{Reason: errors.Errorf("expected new header time %v to be after last header time %v",
 			newHeader.Time,
-			v.state.LastHeader.Time)
+			trusted.LastHeader.Time)}
 	}
 
-	if !newHeader.Time.Before(now) {
-		return errors.Errorf("new header has a time from the future %v (now: %v)",
+	if v.headerFromFuture(newHeader.Time, now) {
+		return ErrInvalidHeader{Reason: errors.Errorf("new header has a time from the future %v (now: %v, max clock drift: %v)",
 			newHeader.Time,
-			now)
+			now,
+			v.maxClockDrift)}
 	}
 
-	if !newHeader.Time.Before(v.state.LastHeader.Time.Add(v.trustingPeriod)) {
-		return errors.Errorf("new header is too far %v into the future to trust it. trusted period ended at %v",
+	if !newHeader.Time.Before(trusted.LastHeader.Time.Add(v.trustingPeriod)) {
+		return ErrInvalidHeader{Reason: errors.Errorf("new header is too far %v into the future to trust it. trusted period ended at %v",
 			newHeader.Time,
-			v.state.LastHeader.Time.Add(v.trustingPeriod))
+			trusted.LastHeader.Time.Add(v.trustingPeriod))}
 	}
 
 	if !bytes.Equal(newHeader.ValidatorsHash, vals.Hash()) {
-		return errors.Errorf("expected validators (%X) to match those from new header (%X)",
+		return ErrInvalidHeader{Reason: errors.Errorf("expected validators (%X) to match those from new header (%X)",
 			vals.Hash(),
 			newHeader.NextValidatorsHash,
-		)
+		)}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
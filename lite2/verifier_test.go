@@ -0,0 +1,42 @@
+package lite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifierHeaderFromFuture(t *testing.T) {
+	now := time.Unix(1000, 0)
+	drift := 10 * time.Second
+
+	testCases := []struct {
+		name       string
+		headerTime time.Time
+		want       bool
+	}{
+		{"well within drift", now.Add(5 * time.Second), false},
+		{"just inside the boundary", now.Add(drift).Add(-time.Nanosecond), false},
+		{"exactly at the boundary", now.Add(drift), true},
+		{"past the boundary", now.Add(drift).Add(time.Nanosecond), true},
+		{"in the past", now.Add(-5 * time.Second), false},
+	}
+
+	v := &Verifier{maxClockDrift: drift}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, v.headerFromFuture(tc.headerTime, now))
+		})
+	}
+}
+
+func TestVerifierHeaderFromFuture_NoDrift(t *testing.T) {
+	now := time.Unix(1000, 0)
+	v := &Verifier{} // maxClockDrift defaults to 0
+
+	assert.False(t, v.headerFromFuture(now.Add(-time.Second), now))
+	assert.True(t, v.headerFromFuture(now, now))
+	assert.True(t, v.headerFromFuture(now.Add(time.Second), now))
+}